@@ -0,0 +1,65 @@
+package bogdanfinn_go_wrapper
+
+import (
+	"fmt"
+	http "github.com/bogdanfinn/fhttp"
+	"github.com/bogdanfinn/fhttp/cookiejar"
+	tls_client "github.com/bogdanfinn/tls-client"
+	"golang.org/x/net/publicsuffix"
+	"net/url"
+	"sync"
+)
+
+// publicSuffixCookieJar adapts an fhttp cookiejar.Jar configured with a
+// public suffix list to the tls_client.CookieJar interface, so cookies for
+// eTLDs (e.g. "co.uk", "github.io") are rejected the same way
+// net/http/cookiejar rejects them when given publicsuffix.List. This prevents
+// a Set-Cookie response from one host on a shared suffix from leaking to
+// sibling hosts as a scraper follows redirects.
+type publicSuffixCookieJar struct {
+	jar *cookiejar.Jar
+
+	mu  sync.RWMutex
+	all map[string][]*http.Cookie
+}
+
+// newPublicSuffixCookieJar creates a tls_client.CookieJar backed by psl, or
+// by golang.org/x/net/publicsuffix.List when psl is nil.
+func newPublicSuffixCookieJar(psl cookiejar.PublicSuffixList) (tls_client.CookieJar, error) {
+	if psl == nil {
+		psl = publicsuffix.List
+	}
+
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: psl})
+	if err != nil {
+		return nil, fmt.Errorf("error creating public-suffix cookie jar: %w", err)
+	}
+
+	return &publicSuffixCookieJar{jar: jar, all: make(map[string][]*http.Cookie)}, nil
+}
+
+// SetCookies implements http.CookieJar.
+func (j *publicSuffixCookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.all[u.Host] = j.jar.Cookies(u)
+}
+
+// Cookies implements http.CookieJar.
+func (j *publicSuffixCookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// GetAllCookies implements tls_client.CookieJar.
+func (j *publicSuffixCookieJar) GetAllCookies() map[string][]*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	snapshot := make(map[string][]*http.Cookie, len(j.all))
+	for host, cookies := range j.all {
+		snapshot[host] = cookies
+	}
+	return snapshot
+}
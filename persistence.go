@@ -0,0 +1,213 @@
+package bogdanfinn_go_wrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	http "github.com/bogdanfinn/fhttp"
+	"github.com/bogdanfinn/tls-client/profiles"
+	"net/url"
+	"os"
+)
+
+// sessionStateVersion is bumped whenever the SessionState shape changes in a
+// way that would make older snapshots unsafe to load.
+const sessionStateVersion = 1
+
+// ErrIncompatibleSessionState is returned by LoadSession/LoadFromFile when a
+// snapshot's Version does not match the version this build of the wrapper
+// knows how to load.
+type ErrIncompatibleSessionState struct {
+	Got, Want int
+}
+
+func (e *ErrIncompatibleSessionState) Error() string {
+	return fmt.Sprintf("session state: version %d is incompatible with the version this build supports (%d)", e.Got, e.Want)
+}
+
+// HostCookies is a snapshot of the cookies a session held for a single host.
+type HostCookies struct {
+	Host    string         `json:"host"`
+	Cookies []*http.Cookie `json:"cookies"`
+}
+
+// SessionState is a JSON-serializable snapshot of a Session, suitable for
+// warm-starting scrapers across process restarts or distributing
+// pre-authenticated sessions across a pool of workers.
+type SessionState struct {
+	Version         int           `json:"version"`
+	Profile         string        `json:"profile"`
+	Proxy           string        `json:"proxy,omitempty"`
+	UserAgent       string        `json:"userAgent"`
+	SecChUa         string        `json:"secChUa"`
+	SecChUaPlatform string        `json:"secChUaPlatform"`
+	RetryPolicy     string        `json:"retryPolicy,omitempty"`
+	Cookies         []HostCookies `json:"cookies,omitempty"`
+}
+
+// Export snapshots the session's cookies (for every host a request was
+// addressed to, or that SetCookies or SetCookiesWithOptions was called for;
+// hosts reached only by following a redirect are not included), its TLS
+// profile, proxy, default headers and a retry policy identifier.
+func (s *Session) Export() (*SessionState, error) {
+	if !s.IsValid() {
+		return nil, fmt.Errorf("session or client is nil")
+	}
+
+	state := &SessionState{
+		Version:         sessionStateVersion,
+		Profile:         s.Profile.GetClientHelloStr(),
+		Proxy:           s.proxyURL(),
+		UserAgent:       s.UserAgent,
+		SecChUa:         s.SecChUa,
+		SecChUaPlatform: s.SecChUaPlatform,
+		RetryPolicy:     retryPolicyIdentifier(s.RetryPolicy),
+	}
+
+	for _, hostURL := range s.knownHostURLs() {
+		cookies := s.Client.GetCookies(hostURL)
+		if len(cookies) == 0 {
+			continue
+		}
+		state.Cookies = append(state.Cookies, HostCookies{
+			Host:    hostURL.Scheme + "://" + hostURL.Host,
+			Cookies: cookies,
+		})
+	}
+
+	return state, nil
+}
+
+// LoadSession reconstructs a Session from a snapshot produced by Export. The
+// provided config seeds any fields the caller wants to override (e.g. a fully
+// reconstructed RetryPolicy, since only its identifier is persisted). A nil
+// UserAgent, SecChUa, SecChUaPlatform or Profile falls back to the value
+// captured in state; a nil RetryPolicy falls back to DefaultRetryPolicy.
+func LoadSession(state *SessionState, config *SessionConfig) (*Session, error) {
+	if state == nil {
+		return nil, fmt.Errorf("session state cannot be nil")
+	}
+
+	if state.Version != sessionStateVersion {
+		return nil, &ErrIncompatibleSessionState{Got: state.Version, Want: sessionStateVersion}
+	}
+
+	// Work on a local copy so callers reusing one template *SessionConfig
+	// across several LoadSession calls don't have the first snapshot's
+	// values permanently stick in their shared config.
+	var resolved SessionConfig
+	if config != nil {
+		resolved = *config
+	}
+
+	if resolved.UserAgent == nil {
+		resolved.UserAgent = &state.UserAgent
+	}
+	if resolved.SecChUa == nil {
+		resolved.SecChUa = &state.SecChUa
+	}
+	if resolved.SecChUaPlatform == nil {
+		resolved.SecChUaPlatform = &state.SecChUaPlatform
+	}
+	if resolved.Profile == nil {
+		if profile, ok := resolveProfile(state.Profile); ok {
+			resolved.Profile = &profile
+		}
+	}
+
+	session, err := NewSession(&resolved)
+	if err != nil {
+		return nil, fmt.Errorf("error recreating session from state: %w", err)
+	}
+
+	if state.Proxy != "" {
+		if err := session.SetProxy(state.Proxy); err != nil {
+			return nil, fmt.Errorf("error restoring proxy: %w", err)
+		}
+	}
+
+	for _, hostCookies := range state.Cookies {
+		targetURL, err := parseHostURL(hostCookies.Host)
+		if err != nil {
+			return nil, fmt.Errorf("error restoring cookies for %q: %w", hostCookies.Host, err)
+		}
+		session.Client.SetCookies(targetURL, hostCookies.Cookies)
+		session.trackHost(targetURL)
+	}
+
+	return session, nil
+}
+
+// SaveToFile writes the session's exported state to path as JSON.
+func (s *Session) SaveToFile(path string) error {
+	state, err := s.Export()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling session state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("error writing session state to %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadFromFile reads a session snapshot from path and reconstructs a Session,
+// applying any overrides in config the same way LoadSession does.
+func LoadFromFile(path string, config *SessionConfig) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading session state from %q: %w", path, err)
+	}
+
+	var state SessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("error unmarshaling session state: %w", err)
+	}
+
+	return LoadSession(&state, config)
+}
+
+// retryPolicyIdentifier returns a short, stable name for the built-in retry
+// policy types so it can be persisted; custom implementations round-trip as
+// "custom" since their configuration cannot be losslessly serialized.
+func retryPolicyIdentifier(policy RetryPolicy) string {
+	switch policy.(type) {
+	case nil:
+		return ""
+	case ExponentialBackoff:
+		return "exponential-backoff"
+	case DecorrelatedJitter:
+		return "decorrelated-jitter"
+	case StatusCodePolicy:
+		return "status-code"
+	case NetworkErrorPolicy:
+		return "network-error"
+	default:
+		return "custom"
+	}
+}
+
+// resolveProfile looks up the TLS client profile whose ClientHelloID string
+// representation matches name, as recorded by Export.
+func resolveProfile(name string) (profiles.ClientProfile, bool) {
+	if name == "" {
+		return profiles.ClientProfile{}, false
+	}
+	for _, profile := range profiles.MappedTLSClients {
+		if profile.GetClientHelloStr() == name {
+			return profile, true
+		}
+	}
+	return profiles.ClientProfile{}, false
+}
+
+// parseHostURL turns a "scheme://host" string back into a *url.URL usable
+// with the underlying cookie jar.
+func parseHostURL(host string) (*url.URL, error) {
+	return url.Parse(host)
+}
@@ -0,0 +1,197 @@
+package bogdanfinn_go_wrapper
+
+import (
+	"fmt"
+	http "github.com/bogdanfinn/fhttp"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides whether a completed attempt should be retried, and how
+// long to wait before the next one. It is consulted after every attempt except
+// the last one allowed by MaxRetries, with the zero-based attempt index that
+// just finished.
+// err is non-nil only when the attempt failed before or while talking to the
+// server (network/TLS errors); a well-formed response with an unwanted status
+// code is reported via resp with err == nil.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, resp *Response, err error) (retry bool, delay time.Duration)
+}
+
+// ExponentialBackoff retries any failed attempt (err != nil) with a delay of
+// Base*2^attempt, capped at Max and randomized by +/- Jitter (a fraction of
+// the computed delay, e.g. 0.2 for +/-20%).
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p ExponentialBackoff) ShouldRetry(attempt int, resp *Response, err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+	return true, p.delay(attempt)
+}
+
+func (p ExponentialBackoff) delay(attempt int) time.Duration {
+	delay := p.Base << uint(attempt)
+	if p.Max > 0 && (delay > p.Max || delay <= 0) {
+		delay = p.Max
+	}
+	return jittered(delay, p.Jitter)
+}
+
+func jittered(delay time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	spread := float64(delay) * jitter
+	offset := (rand.Float64()*2 - 1) * spread
+	result := delay + time.Duration(offset)
+	if result < 0 {
+		return 0
+	}
+	return result
+}
+
+// DecorrelatedJitter approximates the "decorrelated jitter" backoff strategy:
+// each delay is chosen uniformly at random between Base and Base*3^attempt,
+// capped at Max. Using the attempt index rather than the previous sleep keeps
+// the policy stateless, so a single instance can be shared across sessions.
+type DecorrelatedJitter struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p DecorrelatedJitter) ShouldRetry(attempt int, resp *Response, err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+
+	upper := float64(p.Base)
+	for i := 0; i < attempt; i++ {
+		upper *= 3
+	}
+	if p.Max > 0 && upper > float64(p.Max) {
+		upper = float64(p.Max)
+	}
+
+	delay := time.Duration(float64(p.Base) + rand.Float64()*(upper-float64(p.Base)))
+	return true, delay
+}
+
+// StatusCodePolicy retries responses whose status code is in RetryOn. When
+// RespectRetryAfter is set, a Retry-After response header (seconds or an
+// HTTP-date) overrides the computed delay. Backoff supplies the delay used
+// when no Retry-After header is present or honored; it defaults to a
+// conservative ExponentialBackoff.
+type StatusCodePolicy struct {
+	RetryOn           []int
+	RespectRetryAfter bool
+	Backoff           RetryPolicy
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p StatusCodePolicy) ShouldRetry(attempt int, resp *Response, err error) (bool, time.Duration) {
+	if err != nil || resp == nil {
+		return false, 0
+	}
+
+	retryable := false
+	for _, code := range p.RetryOn {
+		if resp.StatusCode == code {
+			retryable = true
+			break
+		}
+	}
+	if !retryable {
+		return false, 0
+	}
+
+	if p.RespectRetryAfter {
+		if delay, ok := parseRetryAfter(resp.Headers); ok {
+			return true, delay
+		}
+	}
+
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff{Base: 200 * time.Millisecond, Max: 10 * time.Second, Jitter: 0.2}
+	}
+
+	_, delay := backoff.ShouldRetry(attempt, resp, fmt.Errorf("retryable status code %d", resp.StatusCode))
+	return true, delay
+}
+
+// parseRetryAfter reads the Retry-After header in either its seconds or
+// HTTP-date form, per RFC 9110 10.2.3.
+func parseRetryAfter(headers http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(headers.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		delay := time.Until(when)
+		if delay < 0 {
+			return 0, true
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// NetworkErrorPolicy retries transient network/TLS/proxy failures identified
+// by matching the error message against a list of known substrings. Delay is
+// computed by Backoff, defaulting to a conservative ExponentialBackoff.
+type NetworkErrorPolicy struct {
+	Substrings []string
+	Backoff    RetryPolicy
+}
+
+// DefaultRetryPolicy returns the policy used by NewSession when
+// SessionConfig.RetryPolicy is not set: retry on the client's known set of
+// transient network errors with exponential backoff.
+func DefaultRetryPolicy() RetryPolicy {
+	return NetworkErrorPolicy{Substrings: sessionErrors}
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p NetworkErrorPolicy) ShouldRetry(attempt int, resp *Response, err error) (bool, time.Duration) {
+	if err == nil {
+		return false, 0
+	}
+
+	message := err.Error()
+	matched := false
+	for _, substring := range p.Substrings {
+		if strings.Contains(message, substring) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false, 0
+	}
+
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff{Base: 100 * time.Millisecond, Max: 5 * time.Second, Jitter: 0.1}
+	}
+
+	return backoff.ShouldRetry(attempt, resp, err)
+}
@@ -0,0 +1,282 @@
+package bogdanfinn_go_wrapper
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RunnerOptions configures a Runner.
+type RunnerOptions struct {
+	// Concurrency caps how many requests are in flight at once, across all
+	// sessions. Defaults to 1 if left at zero.
+	Concurrency int
+
+	// ShardByHost routes every request for a given host to the same session
+	// (hashed by host), preserving cookie affinity across a run instead of
+	// round-robining requests for the same site across unrelated sessions.
+	ShardByHost bool
+}
+
+// RunnerStats summarizes the latencies and outcomes of the requests a Runner
+// has driven against a single session (worker) so far.
+type RunnerStats struct {
+	Session    int    // index into the Runner's sessions slice
+	Proxy      string `json:"proxy,omitempty"`
+	Total      int64
+	Errors     int64
+	ErrorRate  float64
+	Throughput float64 // requests per second
+	Min        time.Duration
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+}
+
+// workerStats accumulates the raw samples for a single session over the
+// Runner's lifetime; Stats reads them without resetting them.
+type workerStats struct {
+	latencies []time.Duration
+	errors    int64
+	total     int64
+}
+
+// Runner drives many GET requests concurrently across a pool of sessions,
+// enforcing a global concurrency limit and collecting per-session latency/
+// error metrics. It is aimed at benchmarking and high-volume scraping
+// workloads, where the point is usually to compare sessions/proxies against
+// each other rather than just see an aggregate.
+type Runner struct {
+	sessions []*Session
+	opts     RunnerOptions
+
+	mu       sync.Mutex
+	workers  []workerStats // one entry per session, aligned by index
+	started  time.Time
+	finished time.Time
+}
+
+// NewRunner creates a Runner that drives requests across sessions.
+func NewRunner(sessions []*Session, opts RunnerOptions) *Runner {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	return &Runner{
+		sessions: sessions,
+		opts:     opts,
+		workers:  make([]workerStats, len(sessions)),
+	}
+}
+
+// Do issues requests concurrently, round-robining across the runner's
+// sessions (or sharding by host when RunnerOptions.ShardByHost is set), and
+// streams each Response on the returned channel as it completes. The channel
+// is closed once every request has been attempted or, after ctx is canceled,
+// once the requests already in flight have returned; no new requests start
+// after cancellation, and responses that complete after it may be dropped.
+//
+// Requests are always issued as GET; use dedicated sessions and Session.Post
+// etc. directly for other methods.
+func (r *Runner) Do(ctx context.Context, requests []RequestOptions) <-chan Response {
+	out := make(chan Response)
+
+	go func() {
+		defer close(out)
+
+		if len(r.sessions) == 0 {
+			return
+		}
+
+		r.mu.Lock()
+		r.started = time.Now()
+		r.mu.Unlock()
+
+		semaphore := make(chan struct{}, r.opts.Concurrency)
+		var wg sync.WaitGroup
+
+	requestLoop:
+		for i, request := range requests {
+			select {
+			case <-ctx.Done():
+				break requestLoop
+			case semaphore <- struct{}{}:
+			}
+
+			idx := r.pickSession(i, request)
+
+			wg.Add(1)
+			go func(req RequestOptions, idx int) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				start := time.Now()
+				resp := r.sessions[idx].Get(req)
+				r.record(idx, time.Since(start), resp)
+
+				select {
+				case out <- resp:
+				case <-ctx.Done():
+				}
+			}(request, idx)
+		}
+
+		wg.Wait()
+
+		r.mu.Lock()
+		r.finished = time.Now()
+		r.mu.Unlock()
+	}()
+
+	return out
+}
+
+// pickSession chooses the index of the session that should carry request i.
+func (r *Runner) pickSession(i int, request RequestOptions) int {
+	if !r.opts.ShardByHost {
+		return i % len(r.sessions)
+	}
+
+	host := ""
+	if parsed, err := url.Parse(request.Url); err == nil {
+		host = parsed.Host
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(host))
+	return int(h.Sum32() % uint32(len(r.sessions)))
+}
+
+// record folds a completed attempt's latency and outcome into the stats kept
+// for the session at idx.
+func (r *Runner) record(idx int, latency time.Duration, resp Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w := &r.workers[idx]
+	w.total++
+	w.latencies = append(w.latencies, latency)
+	if resp.Error != "" {
+		w.errors++
+	}
+}
+
+// Stats returns a snapshot of the latency and error metrics for every
+// session, in the same order as the sessions passed to NewRunner, so a
+// slow or error-prone proxy can be told apart from the rest of the pool.
+func (r *Runner) Stats() []RunnerStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elapsed := r.finished.Sub(r.started)
+	if r.finished.IsZero() && !r.started.IsZero() {
+		elapsed = time.Since(r.started)
+	}
+
+	stats := make([]RunnerStats, len(r.workers))
+	for i := range r.workers {
+		stats[i] = r.statsForWorker(i, elapsed)
+	}
+	return stats
+}
+
+// statsForWorker computes the RunnerStats for session i from its accumulated
+// samples. Callers must hold r.mu.
+func (r *Runner) statsForWorker(i int, elapsed time.Duration) RunnerStats {
+	w := r.workers[i]
+
+	s := RunnerStats{Session: i, Proxy: r.sessions[i].proxyURL(), Total: w.total, Errors: w.errors}
+	if w.total > 0 {
+		s.ErrorRate = float64(w.errors) / float64(w.total)
+	}
+	if elapsed > 0 {
+		s.Throughput = float64(w.total) / elapsed.Seconds()
+	}
+
+	if len(w.latencies) == 0 {
+		return s
+	}
+
+	sorted := make([]time.Duration, len(w.latencies))
+	copy(sorted, w.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	s.Min = sorted[0]
+	s.Max = sorted[len(sorted)-1]
+	s.P50 = percentileDuration(sorted, 0.50)
+	s.P95 = percentileDuration(sorted, 0.95)
+	s.P99 = percentileDuration(sorted, 0.99)
+
+	return s
+}
+
+// percentileDuration returns the value at percentile p (0..1) of a
+// pre-sorted slice.
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	index := int(p * float64(len(sorted)))
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// SessionPool lazily builds sessions from a SessionConfig template, one per
+// proxy in proxies, so a Runner can spread high-volume scraping load across
+// many exit IPs without hand-wiring each session.
+type SessionPool struct {
+	template SessionConfig
+	proxies  []string
+
+	mu       sync.Mutex
+	sessions []*Session
+}
+
+// NewSessionPool creates a pool that will build one session per entry in
+// proxies from template on first use.
+func NewSessionPool(template SessionConfig, proxies []string) *SessionPool {
+	return &SessionPool{
+		template: template,
+		proxies:  proxies,
+	}
+}
+
+// Sessions returns the pool's sessions, constructing them on the first call
+// and reusing them afterward.
+func (p *SessionPool) Sessions() ([]*Session, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sessions != nil {
+		return p.sessions, nil
+	}
+
+	sessions := make([]*Session, 0, len(p.proxies))
+	for _, proxy := range p.proxies {
+		config := p.template
+
+		session, err := NewSession(&config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating pooled session: %w", err)
+		}
+
+		if proxy != "" {
+			if err := session.SetProxy(proxy); err != nil {
+				return nil, fmt.Errorf("error setting proxy for pooled session: %w", err)
+			}
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	p.sessions = sessions
+	return sessions, nil
+}
@@ -0,0 +1,127 @@
+package bogdanfinn_go_wrapper
+
+import (
+	"fmt"
+	http "github.com/bogdanfinn/fhttp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GetStream performs a GET request without buffering the response body,
+// letting callers consume large downloads or Server-Sent Events
+// incrementally. The caller is responsible for closing StreamResponse.Body.
+func (s *Session) GetStream(request RequestOptions) (*StreamResponse, error) {
+	return s.executeStreamRequest(http.MethodGet, request)
+}
+
+// PostStream is the streaming counterpart of Post.
+func (s *Session) PostStream(request RequestOptions) (*StreamResponse, error) {
+	return s.executeStreamRequest(http.MethodPost, request)
+}
+
+// executeStreamRequest mirrors executeRequest but returns the response as
+// soon as headers arrive, without reading the body. Retries are only
+// attempted for failures that happen before any byte of the response has
+// been received (i.e. Client.Do itself failing) — once a StreamResponse has
+// been handed back, bytes may already be flowing to the caller and can't be
+// safely replayed.
+func (s *Session) executeStreamRequest(method string, request RequestOptions) (*StreamResponse, error) {
+	if !s.IsValid() {
+		return nil, fmt.Errorf("session or client is nil")
+	}
+
+	if strings.TrimSpace(request.Url) == "" {
+		return nil, fmt.Errorf("URL cannot be empty")
+	}
+
+	parsedUrl, err := buildURL(request.Url, request.Params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL: %w", err)
+	}
+
+	if targetURL, parseErr := url.Parse(parsedUrl); parseErr == nil {
+		s.trackHost(targetURL)
+	}
+
+	bodyReader, contentType, err := formatBody(request.Headers, request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting body: %w", err)
+	}
+
+	if contentType != "" && strings.Contains(contentType, "multipart/form-data") {
+		if request.Headers == nil {
+			request.Headers = make(map[string]string)
+		}
+		request.Headers["content-type"] = contentType
+	}
+
+	req, err := http.NewRequest(method, parsedUrl, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	headers, err := formatHeaders(s, request.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting headers: %w", err)
+	}
+	req.Header = headers
+
+	policy := s.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	maxRetries := s.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+
+	var lastErr error
+	attempts := 0
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, fmt.Errorf("error rewinding request body for retry: %w", bodyErr)
+			}
+			req.Body = body
+		}
+
+		resp, doErr := s.Client.Do(req)
+		attempts++
+		if doErr == nil {
+			respURL := req.URL
+			if location, locErr := resp.Location(); locErr == nil && location != nil {
+				respURL = location
+			}
+
+			return &StreamResponse{
+				Url:        respURL,
+				Body:       resp.Body,
+				Headers:    resp.Header,
+				StatusCode: resp.StatusCode,
+				Cookies:    resp.Cookies(),
+			}, nil
+		}
+
+		lastErr = doErr
+
+		if attempt == maxRetries-1 {
+			break
+		}
+
+		retry, delay := policy.ShouldRetry(attempt, nil, lastErr)
+		if !retry {
+			break
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+	}
+
+	if attempts < maxRetries {
+		return nil, fmt.Errorf("request error: %w", lastErr)
+	}
+	return nil, fmt.Errorf("max retries (%d) exceeded: %w", maxRetries, lastErr)
+}
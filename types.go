@@ -4,26 +4,42 @@ import (
 	http "github.com/bogdanfinn/fhttp"
 	tls_client "github.com/bogdanfinn/tls-client"
 	"github.com/bogdanfinn/tls-client/profiles"
+	"io"
 	"net/url"
+	"sync"
+	"time"
 )
 
 // Session represents an HTTP session with TLS client
 type Session struct {
-	Client          tls_client.HttpClient
-	UserAgent       string
-	SecChUa         string
-	SecChUaPlatform string
-	MaxRetries      int
+	Client            tls_client.HttpClient
+	UserAgent         string
+	SecChUa           string
+	SecChUaPlatform   string
+	MaxRetries        int
+	SecureCookieCodec SecureCookieEncoder
+	RetryPolicy       RetryPolicy
+	Profile           profiles.ClientProfile
+
+	// mu guards proxy (set by SetProxy) and knownHosts (updated on every
+	// request), both of which are read back by Export; a single Session is
+	// routinely shared across goroutines (e.g. by Runner).
+	mu         sync.Mutex
+	proxy      string
+	knownHosts map[string]*url.URL
 }
 
 // SessionConfig holds configuration for creating a new session
 type SessionConfig struct {
-	UserAgent       *string
-	SecChUa         *string
-	SecChUaPlatform *string
-	Timeout         *int
-	Profile         *profiles.ClientProfile
-	MaxRetries      *int
+	UserAgent         *string
+	SecChUa           *string
+	SecChUaPlatform   *string
+	Timeout           *int
+	Profile           *profiles.ClientProfile
+	MaxRetries        *int
+	SecureCookieCodec SecureCookieEncoder
+	RetryPolicy       RetryPolicy
+	PublicSuffixList  bool
 }
 
 // Response represents an HTTP response
@@ -34,6 +50,39 @@ type Response struct {
 	Headers    http.Header
 	Cookies    []*http.Cookie
 	Error      string
+	TLS        *TLSInfo        `json:"tls,omitempty"`
+	RemoteAddr string          `json:"remoteAddr,omitempty"`
+	Protocol   string          `json:"protocol,omitempty"`
+	Timings    *RequestTimings `json:"timings,omitempty"`
+}
+
+// TLSInfo captures the negotiated TLS connection parameters and peer certificate
+// details for a request, derived from the underlying *http.Response.TLS.
+type TLSInfo struct {
+	ServerName         string             `json:"serverName,omitempty"`
+	NegotiatedProtocol string             `json:"negotiatedProtocol,omitempty"`
+	CipherSuite        uint16             `json:"cipherSuite,omitempty"`
+	Version            uint16             `json:"version,omitempty"`
+	PeerCertificates   []*PeerCertificate `json:"peerCertificates,omitempty"`
+}
+
+// PeerCertificate summarizes the fields of a certificate presented by the
+// server during the TLS handshake.
+type PeerCertificate struct {
+	DNSNames []string  `json:"dnsNames,omitempty"`
+	IPs      []string  `json:"ips,omitempty"`
+	Issuer   string    `json:"issuer,omitempty"`
+	NotAfter time.Time `json:"notAfter,omitempty"`
+}
+
+// RequestTimings breaks down how long each phase of a request took, captured
+// via an httptrace.ClientTrace attached in executeRequest.
+type RequestTimings struct {
+	DNSLookup    time.Duration `json:"dnsLookup,omitempty"`
+	Connect      time.Duration `json:"connect,omitempty"`
+	TLSHandshake time.Duration `json:"tlsHandshake,omitempty"`
+	FirstByte    time.Duration `json:"firstByte,omitempty"`
+	Total        time.Duration `json:"total,omitempty"`
 }
 
 // RequestOptions holds options for making an HTTP request
@@ -42,4 +91,25 @@ type RequestOptions struct {
 	Headers map[string]string
 	Body    interface{}
 	Params  map[string]string
+
+	// Stream marks a request as intended for GetStream/PostStream rather than
+	// the buffering Get/Post/etc; those methods reject it with an error
+	// instead of silently buffering.
+	Stream bool
+
+	// MaxBodyBytes caps how much of a non-streaming response body is read;
+	// zero means unlimited. Guards against a hostile server claiming a huge
+	// Content-Length or streaming an unbounded body.
+	MaxBodyBytes int64
+}
+
+// StreamResponse represents an HTTP response whose body has not been read
+// yet, so large downloads or Server-Sent Events can be consumed incrementally
+// instead of being buffered in full. The caller must close Body.
+type StreamResponse struct {
+	Url        *url.URL
+	Body       io.ReadCloser
+	Headers    http.Header
+	StatusCode int
+	Cookies    []*http.Cookie
 }
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	http "github.com/bogdanfinn/fhttp"
+	httptrace "github.com/bogdanfinn/fhttp/httptrace"
 	"io"
 	"mime/multipart"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Format headers with default values and error handling
@@ -175,8 +177,110 @@ func buildURL(baseURL string, params map[string]string) (string, error) {
 	return parsedURL.String(), nil
 }
 
+// requestTrace collects per-attempt connection timing and peer address via an
+// httptrace.ClientTrace, so executeRequest can surface it on Response.Timings.
+type requestTrace struct {
+	start          time.Time
+	dnsStart       time.Time
+	connectStart   time.Time
+	tlsHandshakeAt time.Time
+	connectDoneAt  time.Time
+	gotConnAt      time.Time
+	firstByteAt    time.Time
+	remoteAddr     string
+}
+
+// newRequestTrace builds a requestTrace and the httptrace.ClientTrace that
+// feeds it. The returned trace should be attached to the request context via
+// httptrace.WithClientTrace before the request is sent.
+func newRequestTrace() (*requestTrace, *httptrace.ClientTrace) {
+	rt := &requestTrace{start: time.Now()}
+
+	clientTrace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			rt.dnsStart = time.Now()
+		},
+		ConnectStart: func(string, string) {
+			rt.connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			rt.connectDoneAt = time.Now()
+		},
+		TLSHandshakeStart: func() {
+			rt.tlsHandshakeAt = time.Now()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			rt.gotConnAt = time.Now()
+			if info.Conn != nil {
+				rt.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+		},
+		GotFirstResponseByte: func() {
+			rt.firstByteAt = time.Now()
+		},
+	}
+
+	return rt, clientTrace
+}
+
+// timings converts the raw timestamps collected during the request into a
+// RequestTimings snapshot. total marks the point the caller considers the
+// request finished (e.g. after the body has been read).
+func (rt *requestTrace) timings(total time.Time) *RequestTimings {
+	if rt == nil {
+		return nil
+	}
+
+	timings := &RequestTimings{Total: total.Sub(rt.start)}
+
+	if !rt.dnsStart.IsZero() && !rt.connectStart.IsZero() {
+		timings.DNSLookup = rt.connectStart.Sub(rt.dnsStart)
+	}
+	if !rt.connectStart.IsZero() && !rt.connectDoneAt.IsZero() {
+		timings.Connect = rt.connectDoneAt.Sub(rt.connectStart)
+	}
+	if !rt.tlsHandshakeAt.IsZero() && !rt.gotConnAt.IsZero() {
+		timings.TLSHandshake = rt.gotConnAt.Sub(rt.tlsHandshakeAt)
+	}
+	if !rt.firstByteAt.IsZero() {
+		timings.FirstByte = rt.firstByteAt.Sub(rt.start)
+	}
+
+	return timings
+}
+
+// buildTLSInfo extracts negotiated TLS parameters and peer certificate
+// summaries from the underlying response's TLS connection state.
+func buildTLSInfo(resp *http.Response) *TLSInfo {
+	if resp == nil || resp.TLS == nil {
+		return nil
+	}
+
+	state := resp.TLS
+	info := &TLSInfo{
+		ServerName:         state.ServerName,
+		NegotiatedProtocol: state.NegotiatedProtocol,
+		CipherSuite:        state.CipherSuite,
+		Version:            state.Version,
+	}
+
+	for _, cert := range state.PeerCertificates {
+		peer := &PeerCertificate{
+			DNSNames: cert.DNSNames,
+			Issuer:   cert.Issuer.String(),
+			NotAfter: cert.NotAfter,
+		}
+		for _, ip := range cert.IPAddresses {
+			peer.IPs = append(peer.IPs, ip.String())
+		}
+		info.PeerCertificates = append(info.PeerCertificates, peer)
+	}
+
+	return info
+}
+
 // Response handler with detailed error reporting
-func handleResponse(s *Session, request RequestOptions, req *http.Request, err error) Response {
+func handleResponse(s *Session, request RequestOptions, req *http.Request, trace *requestTrace, err error) Response {
 	emptyResponse := Response{
 		Url:        nil,
 		Cookies:    nil,
@@ -221,13 +325,29 @@ func handleResponse(s *Session, request RequestOptions, req *http.Request, err e
 		respURL = location
 	}
 
-	// Read body
-	body, err := io.ReadAll(resp.Body)
+	// Read body, capping it at MaxBodyBytes (if set) so a hostile server
+	// can't OOM the client via a huge or unbounded response.
+	var bodyReader io.Reader = resp.Body
+	if request.MaxBodyBytes > 0 {
+		bodyReader = io.LimitReader(resp.Body, request.MaxBodyBytes+1)
+	}
+
+	body, err := io.ReadAll(bodyReader)
 	if err != nil {
 		emptyResponse.Error = fmt.Sprintf("Error reading response body: %v", err)
 		return emptyResponse
 	}
 
+	if request.MaxBodyBytes > 0 && int64(len(body)) > request.MaxBodyBytes {
+		emptyResponse.Error = fmt.Sprintf("response body exceeds MaxBodyBytes limit (%d bytes)", request.MaxBodyBytes)
+		return emptyResponse
+	}
+
+	remoteAddr := ""
+	if trace != nil {
+		remoteAddr = trace.remoteAddr
+	}
+
 	return Response{
 		Url:        respURL,
 		Cookies:    resp.Cookies(),
@@ -235,6 +355,10 @@ func handleResponse(s *Session, request RequestOptions, req *http.Request, err e
 		Body:       body,
 		StatusCode: resp.StatusCode,
 		Error:      "",
+		TLS:        buildTLSInfo(resp),
+		RemoteAddr: remoteAddr,
+		Protocol:   resp.Proto,
+		Timings:    trace.timings(time.Now()),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 	http "github.com/bogdanfinn/fhttp"
+	httptrace "github.com/bogdanfinn/fhttp/httptrace"
 	tls_client "github.com/bogdanfinn/tls-client"
 	"github.com/bogdanfinn/tls-client/profiles"
 )
@@ -16,7 +17,16 @@ func NewSession(config *SessionConfig) (*Session, error) {
 		config = &SessionConfig{}
 	}
 
-	jar := tls_client.NewCookieJar()
+	var jar tls_client.CookieJar
+	if config.PublicSuffixList {
+		var err error
+		jar, err = newPublicSuffixCookieJar(nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		jar = tls_client.NewCookieJar()
+	}
 	if jar == nil {
 		return nil, fmt.Errorf("failed to create cookie jar")
 	}
@@ -37,15 +47,68 @@ func NewSession(config *SessionConfig) (*Session, error) {
 		return nil, fmt.Errorf("error creating TLS session: %w", err)
 	}
 
+	retryPolicy := config.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	return &Session{
-		Client:          client,
-		UserAgent:       getStringOrDefault(config.UserAgent, user_agent),
-		SecChUa:         getStringOrDefault(config.SecChUa, sech_ua),
-		SecChUaPlatform: getStringOrDefault(config.SecChUaPlatform, sech_ua_platform),
-		MaxRetries:      getIntOrDefault(config.MaxRetries, 3),
+		Client:            client,
+		UserAgent:         getStringOrDefault(config.UserAgent, user_agent),
+		SecChUa:           getStringOrDefault(config.SecChUa, sech_ua),
+		SecChUaPlatform:   getStringOrDefault(config.SecChUaPlatform, sech_ua_platform),
+		MaxRetries:        getIntOrDefault(config.MaxRetries, 3),
+		SecureCookieCodec: config.SecureCookieCodec,
+		RetryPolicy:       retryPolicy,
+		Profile:           profile,
+		knownHosts:        make(map[string]*url.URL),
 	}, nil
 }
 
+// trackHost remembers targetURL's host so Export can later snapshot the
+// cookies the session holds for every host it has actually talked to. Safe
+// for concurrent use, since a single Session is routinely shared across
+// goroutines (e.g. by Runner).
+func (s *Session) trackHost(targetURL *url.URL) {
+	if targetURL == nil || targetURL.Host == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.knownHosts == nil {
+		s.knownHosts = make(map[string]*url.URL)
+	}
+	s.knownHosts[targetURL.Scheme+"://"+targetURL.Host] = targetURL
+}
+
+// knownHostURLs returns a snapshot of the hosts tracked so far.
+func (s *Session) knownHostURLs() []*url.URL {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	urls := make([]*url.URL, 0, len(s.knownHosts))
+	for _, u := range s.knownHosts {
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// proxyURL returns the session's currently configured proxy.
+func (s *Session) proxyURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.proxy
+}
+
+// WithRetryPolicy sets the session's retry policy and returns the session,
+// enabling call chaining (e.g. session.WithRetryPolicy(...).Get(...)).
+func (s *Session) WithRetryPolicy(policy RetryPolicy) *Session {
+	s.RetryPolicy = policy
+	return s
+}
+
 // NewSessionLegacy maintains backward compatibility with the old constructor
 func NewSessionLegacy(ua, s_ua, s_ua_platform *string, timeout *int) (*Session, error) {
 	config := &SessionConfig{
@@ -77,6 +140,12 @@ func (s *Session) executeRequest(method string, request RequestOptions) Response
 		}
 	}
 
+	if request.Stream {
+		return Response{
+			Error: "request.Stream is set; use GetStream/PostStream instead",
+		}
+	}
+
 	parsedUrl, err := buildURL(request.Url, request.Params)
 	if err != nil {
 		return Response{
@@ -84,6 +153,10 @@ func (s *Session) executeRequest(method string, request RequestOptions) Response
 		}
 	}
 
+	if targetURL, err := url.Parse(parsedUrl); err == nil {
+		s.trackHost(targetURL)
+	}
+
 	// Format body for methods that support it
 	var bodyReader, contentType, bodyErr = formatBody(request.Headers, request.Body)
 	if bodyErr != nil {
@@ -115,32 +188,68 @@ func (s *Session) executeRequest(method string, request RequestOptions) Response
 	return s.executeWithRetry(request, req)
 }
 
-// executeWithRetry handles the request execution with retry logic
+// executeWithRetry handles the request execution with retry logic. The
+// request body is rewound between attempts via req.GetBody (populated by
+// http.NewRequest for the reader types formatBody produces), so retries can
+// safely replay a body that was already sent on a prior attempt. Each attempt
+// gets its own connection trace, attached to a fresh context derived from the
+// request's original (trace-free) context, so Response.Timings/TLS always
+// reflect the attempt that actually produced the returned response rather
+// than an earlier, failed one.
 func (s *Session) executeWithRetry(request RequestOptions, req *http.Request) Response {
-	var lastResponse Response
+	policy := s.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
 	maxRetries := s.MaxRetries
 	if maxRetries <= 0 {
 		maxRetries = 1 // At least one attempt
 	}
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		resp := handleResponse(s, request, req, nil)
+	baseCtx := req.Context()
 
-		// If no session error, return the response
-		if resp.Error == "" {
-			return resp
+	var lastResponse Response
+	attempts := 0
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				lastResponse.Error = fmt.Sprintf("error rewinding request body for retry: %v", err)
+				return lastResponse
+			}
+			req.Body = body
 		}
 
+		trace, clientTrace := newRequestTrace()
+		req = req.WithContext(httptrace.WithClientTrace(baseCtx, clientTrace))
+
+		resp := handleResponse(s, request, req, trace, nil)
 		lastResponse = resp
+		attempts++
 
-		// Don't wait on the last attempt
-		if attempt < maxRetries-1 {
-			time.Sleep(time.Millisecond * 100 * time.Duration(attempt+1)) // Exponential backoff
+		var attemptErr error
+		if resp.Error != "" {
+			attemptErr = fmt.Errorf("%s", resp.Error)
+		}
+
+		if attempt == maxRetries-1 {
+			break
+		}
+
+		retry, delay := policy.ShouldRetry(attempt, &resp, attemptErr)
+		if !retry {
+			break
+		}
+
+		if delay > 0 {
+			time.Sleep(delay)
 		}
 	}
 
-	// If we get here, all retries failed
-	lastResponse.Error = fmt.Sprintf("max retries (%d) exceeded: %s", maxRetries, lastResponse.Error)
+	if lastResponse.Error != "" && attempts == maxRetries {
+		lastResponse.Error = fmt.Sprintf("max retries (%d) exceeded: %s", maxRetries, lastResponse.Error)
+	}
 	return lastResponse
 }
 
@@ -203,6 +312,7 @@ func (s *Session) SetCookies(name, value string, targetURL *url.URL) error {
 	}
 
 	s.Client.SetCookies(targetURL, []*http.Cookie{cookie})
+	s.trackHost(targetURL)
 	return nil
 }
 
@@ -225,6 +335,7 @@ func (s *Session) SetCookiesWithOptions(cookie *http.Cookie, targetURL *url.URL)
 	}
 
 	s.Client.SetCookies(targetURL, []*http.Cookie{cookie})
+	s.trackHost(targetURL)
 	return nil
 }
 
@@ -244,6 +355,11 @@ func (s *Session) SetProxy(proxy string) error {
 	}
 
 	s.Client.SetProxy(proxy)
+
+	s.mu.Lock()
+	s.proxy = proxy
+	s.mu.Unlock()
+
 	return nil
 }
 
@@ -259,6 +375,11 @@ func (s *Session) ClearCookies() error {
 	}
 
 	s.Client.SetCookieJar(jar)
+
+	s.mu.Lock()
+	s.knownHosts = make(map[string]*url.URL)
+	s.mu.Unlock()
+
 	return nil
 }
 